@@ -0,0 +1,28 @@
+package geek
+
+// ByteView holds an immutable view of bytes, shared freely between the
+// cache and its callers without risk of either mutating the other's copy.
+type ByteView struct {
+	b []byte
+}
+
+// Len returns the length of the view, satisfying lru.Value.
+func (v ByteView) Len() int {
+	return len(v.b)
+}
+
+// ByteSlice returns a copy of the underlying data as a byte slice.
+func (v ByteView) ByteSlice() []byte {
+	return cloneBytes(v.b)
+}
+
+// String returns the data as a string, making a copy when necessary.
+func (v ByteView) String() string {
+	return string(v.b)
+}
+
+func cloneBytes(b []byte) []byte {
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
@@ -0,0 +1,50 @@
+package geek
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Makonike/geek-cache/geek/lru"
+)
+
+// cache wraps lru.Cache with a mutex and lazy initialization, since a Group
+// may never be written to if every request is a miss.
+type cache struct {
+	mu         sync.Mutex
+	lru        *lru.Cache
+	cacheBytes int64
+}
+
+func (c *cache) add(key string, value ByteView) {
+	c.addWithTTL(key, value, 0)
+}
+
+func (c *cache) addWithTTL(key string, value ByteView, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lru == nil {
+		c.lru = lru.New(c.cacheBytes, nil)
+	}
+	c.lru.AddWithTTL(key, value, ttl)
+}
+
+func (c *cache) get(key string) (ByteView, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lru == nil {
+		return ByteView{}, false
+	}
+	if v, ok := c.lru.Get(key); ok {
+		return v.(ByteView), true
+	}
+	return ByteView{}, false
+}
+
+func (c *cache) remove(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lru == nil {
+		return false
+	}
+	return c.lru.Remove(key)
+}
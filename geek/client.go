@@ -0,0 +1,79 @@
+package geek
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/Makonike/geek-cache/geek/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const clientCallTimeout = 10 * time.Second
+
+// Client is a gRPC PeerGetter for a single remote geek-cache node. It dials
+// lazily on each call rather than holding a persistent connection, keeping
+// it simple at the cost of per-call dial overhead.
+type Client struct {
+	addr        string
+	serviceName string
+	dialOpts    []grpc.DialOption
+}
+
+// NewClient returns a Client targeting addr. dialOpts is applied in addition
+// to an insecure transport credential.
+func NewClient(addr, serviceName string, dialOpts ...grpc.DialOption) *Client {
+	return &Client{addr: addr, serviceName: serviceName, dialOpts: dialOpts}
+}
+
+func (c *Client) dial() (*grpc.ClientConn, error) {
+	opts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, c.dialOpts...)
+	return grpc.Dial(c.addr, opts...)
+}
+
+func (c *Client) Get(group, key string) ([]byte, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), clientCallTimeout)
+	defer cancel()
+	resp, err := pb.NewGroupCacheServerClient(conn).Get(ctx, &pb.Request{Group: group, Key: key})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetValue(), nil
+}
+
+func (c *Client) Delete(group, key string) (bool, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), clientCallTimeout)
+	defer cancel()
+	resp, err := pb.NewGroupCacheServerClient(conn).Delete(ctx, &pb.Request{Group: group, Key: key})
+	if err != nil {
+		return false, err
+	}
+	return resp.GetValue(), nil
+}
+
+func (c *Client) Set(group, key string, value []byte, ttl time.Duration) error {
+	conn, err := c.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), clientCallTimeout)
+	defer cancel()
+	_, err = pb.NewGroupCacheServerClient(conn).Set(ctx, &pb.SetRequest{
+		Group: group,
+		Key:   key,
+		Value: value,
+		TtlMs: int64(ttl / time.Millisecond),
+	})
+	return err
+}
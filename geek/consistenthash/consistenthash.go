@@ -0,0 +1,236 @@
+// Package consistenthash implements a consistent-hashing ring of virtual
+// nodes, used by ClientPicker to pick which peer owns a given key.
+package consistenthash
+
+import (
+	"hash/crc32"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// Hash maps bytes to a uint32; pluggable mainly for tests.
+type Hash func(data []byte) uint32
+
+// RingChangeEvent describes the peers added and/or removed by a single
+// Add/Remove call, so higher layers can trigger targeted key migration
+// instead of blindly invalidating everything on every membership change.
+type RingChangeEvent struct {
+	Added   []string
+	Removed []string
+}
+
+// Map is a consistent hash ring of virtual nodes. Besides picking the
+// natural owner of a key, it optionally implements "consistent hashing
+// with bounded loads": Get tracks each peer's current in-flight request
+// count and, once the natural owner is overloaded relative to the
+// cluster's average, walks the ring forward to the next peer with spare
+// capacity.
+type Map struct {
+	hash     Hash
+	replicas int
+	epsilon  float64 // bounded-load slack; <= 0 disables load awareness
+
+	keys    []int // sorted hashes
+	hashMap map[int]string
+	peers   map[string]bool // distinct peer names currently on the ring
+
+	mu    sync.Mutex
+	loads map[string]*int64 // in-flight request count per peer
+
+	onRingChanged func(RingChangeEvent)
+}
+
+// ConsOptions configures a Map at construction time.
+type ConsOptions func(*Map)
+
+// Replicas sets the number of virtual nodes per peer. Default 50.
+func Replicas(r int) ConsOptions {
+	return func(m *Map) { m.replicas = r }
+}
+
+// HashFunc overrides the hash function. Default crc32.ChecksumIEEE.
+func HashFunc(fn Hash) ConsOptions {
+	return func(m *Map) { m.hash = fn }
+}
+
+// BoundedLoad enables consistent hashing with bounded loads: a peer is
+// skipped by Get once its in-flight load exceeds (1+epsilon) times the
+// cluster's average load. epsilon <= 0 disables this (the default),
+// falling back to plain consistent hashing.
+func BoundedLoad(epsilon float64) ConsOptions {
+	return func(m *Map) { m.epsilon = epsilon }
+}
+
+func New(opts ...ConsOptions) *Map {
+	m := &Map{
+		hash:     crc32.ChecksumIEEE,
+		replicas: 50,
+		hashMap:  make(map[int]string),
+		peers:    make(map[string]bool),
+		loads:    make(map[string]*int64),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// SetOnRingChanged registers fn to be called after every Add/Remove that
+// actually changes ring membership.
+func (m *Map) SetOnRingChanged(fn func(RingChangeEvent)) {
+	m.onRingChanged = fn
+}
+
+// Add adds peers to the ring, skipping any already present.
+func (m *Map) Add(peers ...string) {
+	added := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		if m.peers[peer] {
+			continue
+		}
+		m.peers[peer] = true
+		added = append(added, peer)
+		for i := 0; i < m.replicas; i++ {
+			hash := int(m.hash([]byte(strconv.Itoa(i) + peer)))
+			m.keys = append(m.keys, hash)
+			m.hashMap[hash] = peer
+		}
+		m.mu.Lock()
+		if _, ok := m.loads[peer]; !ok {
+			var c int64
+			m.loads[peer] = &c
+		}
+		m.mu.Unlock()
+	}
+	if len(added) == 0 {
+		return
+	}
+	sort.Ints(m.keys)
+	if m.onRingChanged != nil {
+		m.onRingChanged(RingChangeEvent{Added: added})
+	}
+}
+
+// Remove removes peer from the ring.
+func (m *Map) Remove(peer string) {
+	if !m.peers[peer] {
+		return
+	}
+	delete(m.peers, peer)
+	for i := 0; i < m.replicas; i++ {
+		hash := int(m.hash([]byte(strconv.Itoa(i) + peer)))
+		// Only touch this hash if it's actually still owned by peer: two
+		// replicas (of the same or different peers) landing on the same
+		// hash is rare but possible, and blindly deleting here could evict
+		// a slot another peer's replica had already overwritten in
+		// hashMap, or remove a keys entry that in fact still belongs to
+		// that other peer.
+		if m.hashMap[hash] != peer {
+			continue
+		}
+		idx := sort.SearchInts(m.keys, hash)
+		if idx < len(m.keys) && m.keys[idx] == hash {
+			m.keys = append(m.keys[:idx], m.keys[idx+1:]...)
+		}
+		delete(m.hashMap, hash)
+	}
+	m.mu.Lock()
+	delete(m.loads, peer)
+	m.mu.Unlock()
+	if m.onRingChanged != nil {
+		m.onRingChanged(RingChangeEvent{Removed: []string{peer}})
+	}
+}
+
+// Get returns the peer that owns key. When bounded-load is enabled
+// (BoundedLoad), callers should use GetWithLoad instead so the picked
+// peer's load counter is actually tracked; Get alone cannot report the
+// release func needed to decrement it.
+func (m *Map) Get(key string) string {
+	peer, release := m.GetWithLoad(key)
+	release()
+	return peer
+}
+
+// GetWithLoad returns the peer that owns key along with a release func
+// that must be called once the request against that peer completes. If
+// bounded loads are enabled and the natural owner is over capacity, it
+// walks the ring forward to the next peer with spare capacity instead.
+func (m *Map) GetWithLoad(key string) (string, func()) {
+	if len(m.keys) == 0 {
+		return "", func() {}
+	}
+	hash := int(m.hash([]byte(key)))
+	idx := sort.Search(len(m.keys), func(i int) bool { return m.keys[i] >= hash })
+	n := len(m.keys)
+
+	if m.epsilon <= 0 {
+		peer := m.hashMap[m.keys[idx%n]]
+		return peer, m.acquire(peer)
+	}
+
+	capacity := m.capacityLocked()
+	seen := make(map[string]bool, len(m.peers))
+	for i := 0; i < n; i++ {
+		peer := m.hashMap[m.keys[(idx+i)%n]]
+		if seen[peer] {
+			continue
+		}
+		seen[peer] = true
+		if m.loadOf(peer) < capacity {
+			return peer, m.acquire(peer)
+		}
+		if len(seen) == len(m.peers) {
+			break
+		}
+	}
+	// every peer is at/over capacity: fall back to the natural owner
+	// rather than reject the request outright
+	peer := m.hashMap[m.keys[idx%n]]
+	return peer, m.acquire(peer)
+}
+
+// capacityLocked returns the max in-flight load a peer may carry before
+// GetWithLoad routes around it: (1+epsilon) * average load across peers,
+// floored at 1 so a freshly-started, all-zero ring doesn't skip every peer.
+func (m *Map) capacityLocked() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.loads) == 0 {
+		return 1
+	}
+	var total int64
+	for _, c := range m.loads {
+		total += atomic.LoadInt64(c)
+	}
+	capacity := int64((1 + m.epsilon) * float64(total) / float64(len(m.loads)))
+	if capacity < 1 {
+		capacity = 1
+	}
+	return capacity
+}
+
+func (m *Map) loadOf(peer string) int64 {
+	m.mu.Lock()
+	c, ok := m.loads[peer]
+	m.mu.Unlock()
+	if !ok {
+		return 0
+	}
+	return atomic.LoadInt64(c)
+}
+
+func (m *Map) acquire(peer string) func() {
+	m.mu.Lock()
+	c, ok := m.loads[peer]
+	if !ok {
+		var v int64
+		c = &v
+		m.loads[peer] = c
+	}
+	m.mu.Unlock()
+	atomic.AddInt64(c, 1)
+	return func() { atomic.AddInt64(c, -1) }
+}
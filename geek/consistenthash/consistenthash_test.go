@@ -0,0 +1,120 @@
+package consistenthash
+
+import (
+	"strconv"
+	"testing"
+)
+
+// identityHash lets tests place virtual nodes at exact, known positions on
+// the ring instead of reasoning about crc32 output.
+func identityHash(data []byte) uint32 {
+	n, _ := strconv.Atoi(string(data))
+	return uint32(n)
+}
+
+func TestGetReturnsConsistentOwnerAcrossCalls(t *testing.T) {
+	m := New(Replicas(1), HashFunc(identityHash))
+	m.Add("6", "2", "4")
+
+	cases := map[string]string{
+		"2":  "2",
+		"5":  "6",
+		"7":  "2", // wraps around past the largest hash back to the smallest
+		"11": "2",
+	}
+	for key, want := range cases {
+		if got := m.Get(key); got != want {
+			t.Errorf("Get(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestAddIsIdempotentAndFiresRingChangedOnce(t *testing.T) {
+	m := New(Replicas(1), HashFunc(identityHash))
+	var events []RingChangeEvent
+	m.SetOnRingChanged(func(e RingChangeEvent) { events = append(events, e) })
+
+	m.Add("6", "2", "4")
+	m.Add("6") // already present: must not re-fire or duplicate ring entries
+
+	if len(events) != 1 {
+		t.Fatalf("got %d ring-changed events, want 1", len(events))
+	}
+	if got := m.Get("2"); got != "2" {
+		t.Fatalf("Get(2) = %q, want 2", got)
+	}
+}
+
+func TestRemoveDropsOwnershipAndFiresRingChanged(t *testing.T) {
+	m := New(Replicas(1), HashFunc(identityHash))
+	m.Add("6", "2", "4")
+
+	var events []RingChangeEvent
+	m.SetOnRingChanged(func(e RingChangeEvent) { events = append(events, e) })
+
+	m.Remove("2")
+
+	if len(events) != 1 || len(events[0].Removed) != 1 || events[0].Removed[0] != "2" {
+		t.Fatalf("got events %+v, want a single Removed=[2] event", events)
+	}
+	if got := m.Get("11"); got == "2" {
+		t.Fatalf("Get(11) still resolves to removed peer 2")
+	}
+}
+
+func TestRemoveOfUnknownPeerIsNoop(t *testing.T) {
+	m := New(Replicas(1), HashFunc(identityHash))
+	m.Add("6", "2")
+
+	fired := false
+	m.SetOnRingChanged(func(RingChangeEvent) { fired = true })
+
+	m.Remove("99") // never added
+	if fired {
+		t.Fatalf("Remove of an unknown peer fired onRingChanged")
+	}
+	if got := m.Get("2"); got != "2" {
+		t.Fatalf("Get(2) = %q, want 2 (ring unaffected)", got)
+	}
+}
+
+func TestGetWithLoadSpreadsOverloadAcrossPeers(t *testing.T) {
+	// Three single-replica peers at 2, 4, 6. Keep hammering the key whose
+	// natural owner is "2" without releasing; once its in-flight load
+	// outpaces the cluster average by more than epsilon, later calls must
+	// be routed to one of the other, still-idle peers instead of piling
+	// everything onto "2".
+	m := New(Replicas(1), HashFunc(identityHash), BoundedLoad(0.2))
+	m.Add("6", "2", "4")
+
+	seen := make(map[string]bool)
+	var releases []func()
+	for i := 0; i < 20; i++ {
+		peer, release := m.GetWithLoad("2")
+		seen[peer] = true
+		releases = append(releases, release)
+	}
+	for _, release := range releases {
+		release()
+	}
+
+	if len(seen) < 2 {
+		t.Fatalf("GetWithLoad never routed around the saturated natural owner; peers seen: %v", seen)
+	}
+}
+
+func TestGetWithLoadFallsBackToNaturalOwnerWhenAllSaturated(t *testing.T) {
+	m := New(Replicas(1), HashFunc(identityHash), BoundedLoad(0.01))
+	m.Add("2")
+
+	// A single-peer ring can never route "around" an overloaded owner -
+	// GetWithLoad must still return it rather than reject the request.
+	_, release1 := m.GetWithLoad("2")
+	peer, release2 := m.GetWithLoad("2")
+	release1()
+	release2()
+
+	if peer != "2" {
+		t.Fatalf("GetWithLoad(2) = %q on a saturated single-peer ring, want fallback to 2", peer)
+	}
+}
@@ -0,0 +1,191 @@
+package geek
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Getter loads data for a key when it's missing from both the local cache
+// and every peer.
+type Getter interface {
+	Get(key string) ([]byte, error)
+}
+
+// GetterFunc implements Getter with a plain function.
+type GetterFunc func(key string) ([]byte, error)
+
+func (f GetterFunc) Get(key string) ([]byte, error) {
+	return f(key)
+}
+
+// Group is a cache namespace with its own Getter and associated data,
+// loaded locally or, once RegisterPeers is called, from whichever peer
+// owns a given key.
+type Group struct {
+	name      string
+	getter    Getter
+	mainCache cache
+	peers     PeerPicker
+	loadGroup LoadGroup // coalesces concurrent requester-side peer fetches for the same key
+}
+
+var (
+	mu     sync.RWMutex
+	groups = make(map[string]*Group)
+)
+
+// GroupOptions configures optional Group behavior.
+type GroupOptions func(*Group)
+
+// GroupLoadGroup overrides the default, process-local LoadGroup used to
+// coalesce concurrent requester-side fetches from the owning peer, e.g. with
+// one built from NewDistributedLoadGroup for cross-process coalescing on
+// hot keys.
+func GroupLoadGroup(lg LoadGroup) GroupOptions {
+	return func(g *Group) {
+		g.loadGroup = lg
+	}
+}
+
+// NewGroup creates and registers a new Group, panicking if getter is nil or
+// name is already in use.
+func NewGroup(name string, cacheBytes int64, getter Getter, opts ...GroupOptions) *Group {
+	if getter == nil {
+		panic("nil Getter")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if _, dup := groups[name]; dup {
+		panic("duplicate registration of group " + name)
+	}
+	g := &Group{
+		name:      name,
+		getter:    getter,
+		mainCache: cache{cacheBytes: cacheBytes},
+		loadGroup: NewLoadGroup(0),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	groups[name] = g
+	return g
+}
+
+// GetGroup returns the named Group, or nil if it hasn't been created with
+// NewGroup.
+func GetGroup(name string) *Group {
+	mu.RLock()
+	defer mu.RUnlock()
+	return groups[name]
+}
+
+// RegisterPeers registers a PeerPicker for choosing remote peers. It may
+// only be called once per Group.
+func (g *Group) RegisterPeers(peers PeerPicker) {
+	if g.peers != nil {
+		panic("RegisterPeers called more than once")
+	}
+	g.peers = peers
+}
+
+// Get looks up key's value, first in the local cache, then the local hot
+// cache (if any), then from the owning peer, falling back to the Getter on
+// a complete miss.
+func (g *Group) Get(key string) (ByteView, error) {
+	if key == "" {
+		return ByteView{}, fmt.Errorf("key is required")
+	}
+	if v, ok := g.mainCache.get(key); ok {
+		return v, nil
+	}
+	return g.load(key)
+}
+
+func (g *Group) load(key string) (ByteView, error) {
+	if g.peers != nil {
+		if cp, ok := g.peers.(*ClientPicker); ok {
+			if v, ok := cp.HotGet(key); ok {
+				return v, nil
+			}
+		}
+		if view, attempted, err := g.loadFromPeer(key); attempted {
+			if err == nil {
+				return view, nil
+			}
+			log.Printf("[Geek-Cache] failed to get from peer: %v", err)
+		}
+	}
+	return g.getLocally(key)
+}
+
+// loadFromPeer fetches key from its owning peer, if key isn't owned by this
+// node itself. attempted reports whether a remote fetch was actually made
+// (vs. the ring having no owner, or the owner being self), so callers can
+// tell "remote miss" from "fall through to the local Getter". Concurrent
+// callers for the same key coalesce through loadGroup, the same way
+// Server.Get coalesces concurrent requests on the owning side.
+func (g *Group) loadFromPeer(key string) (view ByteView, attempted bool, err error) {
+	peer, ok, isSelf, release := g.peers.PickPeer(key)
+	if !ok {
+		return ByteView{}, false, nil
+	}
+	defer release()
+	if isSelf {
+		return ByteView{}, false, nil
+	}
+
+	view, err = g.loadGroup.Do(g.name+"/"+key, func() (ByteView, error) {
+		bytes, err := peer.Get(g.name, key)
+		if err != nil {
+			return ByteView{}, err
+		}
+		return ByteView{b: bytes}, nil
+	})
+	if err == nil {
+		if cp, ok := g.peers.(*ClientPicker); ok {
+			cp.RecordRemoteHit(key, view)
+		}
+	}
+	return view, true, err
+}
+
+func (g *Group) getLocally(key string) (ByteView, error) {
+	bytes, err := g.getter.Get(key)
+	if err != nil {
+		return ByteView{}, err
+	}
+	value := ByteView{b: cloneBytes(bytes)}
+	g.populateCache(key, value)
+	return value, nil
+}
+
+func (g *Group) populateCache(key string, value ByteView) {
+	g.mainCache.add(key, value)
+}
+
+// Delete removes key from this node's local cache. It does not propagate to
+// peers; callers that need cluster-wide invalidation should use
+// ClientPicker.Set with SetBroadcast instead.
+func (g *Group) Delete(key string) (bool, error) {
+	if key == "" {
+		return false, fmt.Errorf("key is required")
+	}
+	return g.mainCache.remove(key), nil
+}
+
+// Set writes key=value into the local cache with the given ttl (0 means no
+// expiry). It is the local half of ClientPicker.Set's write path:
+// ClientPicker.Set resolves which node owns key and sends the write there
+// over RPC, where Server.Set calls Set on that node's Group directly.
+func (g *Group) Set(key string, value []byte, ttl time.Duration) error {
+	if key == "" {
+		return fmt.Errorf("key is required")
+	}
+	g.mainCache.addWithTTL(key, ByteView{b: cloneBytes(value)}, ttl)
+	if cp, ok := g.peers.(*ClientPicker); ok {
+		cp.InvalidateHot(key)
+	}
+	return nil
+}
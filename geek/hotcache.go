@@ -0,0 +1,133 @@
+package geek
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// hotCacheEntry is the payload stored in hotCache's LRU list.
+type hotCacheEntry struct {
+	key       string
+	value     ByteView
+	expiresAt time.Time // zero means no expiry
+}
+
+// hotCache is a small, bounded, local cache for keys that are owned by a
+// remote peer but are being requested often enough on this node that
+// keeping a local copy is cheaper than paying for an RPC on every request.
+// It is intentionally separate from the main Group cache so hot keys can't
+// evict the node's own entries and vice versa.
+//
+// Promoted entries expire after ttl so a node doesn't keep serving a key
+// indefinitely after its owner's value changes via Set; invalidate gives
+// callers on the same node an explicit hook to drop a key immediately
+// instead of waiting out the ttl.
+type hotCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration // 0 means promoted entries never expire
+	ll         *list.List
+	cache      map[string]*list.Element
+
+	hits, misses, evictions int64
+}
+
+func newHotCache(maxEntries int, ttl time.Duration) *hotCache {
+	return &hotCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		ll:         list.New(),
+		cache:      make(map[string]*list.Element),
+	}
+}
+
+func (c *hotCache) get(key string) (ByteView, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ele, ok := c.cache[key]
+	if !ok {
+		c.misses++
+		return ByteView{}, false
+	}
+	entry := ele.Value.(*hotCacheEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		c.removeElement(ele)
+		c.misses++
+		return ByteView{}, false
+	}
+	c.ll.MoveToFront(ele)
+	c.hits++
+	return entry.value, true
+}
+
+func (c *hotCache) add(key string, value ByteView) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var expiresAt time.Time
+	if c.ttl > 0 {
+		expiresAt = time.Now().Add(c.ttl)
+	}
+	if ele, ok := c.cache[key]; ok {
+		c.ll.MoveToFront(ele)
+		entry := ele.Value.(*hotCacheEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		return
+	}
+	ele := c.ll.PushFront(&hotCacheEntry{key: key, value: value, expiresAt: expiresAt})
+	c.cache[key] = ele
+	if c.maxEntries > 0 && c.ll.Len() > c.maxEntries {
+		c.removeOldest()
+	}
+}
+
+// invalidate drops key from the hot cache, if present. It's the explicit
+// counterpart to ttl-based expiry, for the same-node case where this node
+// both promoted key and later writes it via Group.Set.
+func (c *hotCache) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ele, ok := c.cache[key]; ok {
+		c.removeElement(ele)
+	}
+}
+
+func (c *hotCache) removeOldest() {
+	ele := c.ll.Back()
+	if ele != nil {
+		c.removeElement(ele)
+	}
+}
+
+func (c *hotCache) removeElement(ele *list.Element) {
+	c.ll.Remove(ele)
+	entry := ele.Value.(*hotCacheEntry)
+	delete(c.cache, entry.key)
+	c.evictions++
+}
+
+func (c *hotCache) keys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]string, 0, c.ll.Len())
+	for ele := c.ll.Front(); ele != nil; ele = ele.Next() {
+		keys = append(keys, ele.Value.(*hotCacheEntry).key)
+	}
+	return keys
+}
+
+// HotCacheStats is a snapshot of the hot cache's size and hit/miss/eviction
+// counters, meant to be exported as metrics.
+type HotCacheStats struct {
+	Len       int
+	Hits      int64
+	Misses    int64
+	Evictions int64
+}
+
+func (c *hotCache) stats() HotCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return HotCacheStats{Len: c.ll.Len(), Hits: c.hits, Misses: c.misses, Evictions: c.evictions}
+}
@@ -0,0 +1,43 @@
+package geek
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// HotCacheCollector exports a ClientPicker's hot-cache stats as Prometheus
+// metrics, refreshed from HotCacheStats on every scrape rather than pushed
+// as they change.
+type HotCacheCollector struct {
+	picker *ClientPicker
+
+	entries   *prometheus.Desc
+	hits      *prometheus.Desc
+	misses    *prometheus.Desc
+	evictions *prometheus.Desc
+}
+
+// NewHotCacheCollector returns a prometheus.Collector for picker's hot
+// cache. Register it with a prometheus.Registerer the same way the
+// interceptor package's Metrics interceptor registers its own collectors.
+func NewHotCacheCollector(picker *ClientPicker) *HotCacheCollector {
+	return &HotCacheCollector{
+		picker:    picker,
+		entries:   prometheus.NewDesc("geek_cache_hot_cache_entries", "Current number of keys promoted to the local hot cache.", nil, nil),
+		hits:      prometheus.NewDesc("geek_cache_hot_cache_hits_total", "Total local hot-cache lookups that hit.", nil, nil),
+		misses:    prometheus.NewDesc("geek_cache_hot_cache_misses_total", "Total local hot-cache lookups that missed.", nil, nil),
+		evictions: prometheus.NewDesc("geek_cache_hot_cache_evictions_total", "Total hot-cache entries evicted, by TTL expiry, LRU eviction, or explicit invalidation.", nil, nil),
+	}
+}
+
+func (c *HotCacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.entries
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.evictions
+}
+
+func (c *HotCacheCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.picker.HotCacheStats()
+	ch <- prometheus.MustNewConstMetric(c.entries, prometheus.GaugeValue, float64(stats.Len))
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(stats.Evictions))
+}
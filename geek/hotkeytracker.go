@@ -0,0 +1,66 @@
+package geek
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// hotKeyTracker approximates each key's request rate with a decaying
+// counter: every access bumps the key's count by one, and once per
+// decayEvery interval every count is halved, so the estimate tracks recent
+// traffic instead of an all-time total. It's cheaper to maintain than a
+// count-min sketch while still bounding memory, since counts that decay
+// away are dropped from the map.
+type hotKeyTracker struct {
+	mu          sync.Mutex
+	counts      map[string]float64
+	lastDecay   time.Time
+	decayEvery  time.Duration
+	decayFactor float64
+	threshold   float64
+}
+
+func newHotKeyTracker(qpsThreshold float64) *hotKeyTracker {
+	return &hotKeyTracker{
+		counts:      make(map[string]float64),
+		lastDecay:   time.Now(),
+		decayEvery:  time.Second,
+		decayFactor: 0.5,
+		threshold:   qpsThreshold,
+	}
+}
+
+// touch records an access for key.
+func (t *hotKeyTracker) touch(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.decayLocked()
+	t.counts[key]++
+}
+
+// isHot reports whether key's estimated request rate currently exceeds the
+// configured QPS threshold.
+func (t *hotKeyTracker) isHot(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.counts[key] >= t.threshold
+}
+
+func (t *hotKeyTracker) decayLocked() {
+	intervals := int(time.Since(t.lastDecay) / t.decayEvery)
+	if intervals < 1 {
+		return
+	}
+	// apply one halving per elapsed interval, not just one per access
+	factor := math.Pow(t.decayFactor, float64(intervals))
+	for k, v := range t.counts {
+		v *= factor
+		if v < 0.01 {
+			delete(t.counts, k)
+			continue
+		}
+		t.counts[k] = v
+	}
+	t.lastDecay = t.lastDecay.Add(time.Duration(intervals) * t.decayEvery)
+}
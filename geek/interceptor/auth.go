@@ -0,0 +1,33 @@
+// Package interceptor provides a set of composable gRPC interceptors -
+// auth, tracing, metrics and rate-limiting - meant to be chained together
+// via ServerUnaryInterceptors on the server side and WithDialOptions on the
+// client side, rather than used standalone.
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TokenValidator reports whether token authorizes the incoming call.
+type TokenValidator func(token string) bool
+
+// Auth returns a unary server interceptor that rejects calls missing a
+// valid "authorization" metadata token, as judged by validate.
+func Auth(validate TokenValidator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "missing metadata")
+		}
+		tokens := md.Get("authorization")
+		if len(tokens) == 0 || !validate(tokens[0]) {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return handler(ctx, req)
+	}
+}
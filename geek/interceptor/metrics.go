@@ -0,0 +1,66 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics returns a unary server interceptor that records request counts
+// and a latency histogram, labeled by method and result (ok, or the gRPC
+// status code on error), registered against reg for Prometheus scraping.
+//
+// Metrics may safely be called more than once against the same reg (e.g.
+// one Server constructed per test, or several Servers sharing a process
+// registry): if these collector names are already registered, the
+// already-registered instances are reused instead of panicking.
+func Metrics(reg prometheus.Registerer) grpc.UnaryServerInterceptor {
+	requests := registerCounterVec(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "geek_cache_requests_total",
+		Help: "Total gRPC requests handled, labeled by method and result.",
+	}, []string{"method", "result"}))
+	latency := registerHistogramVec(reg, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "geek_cache_request_duration_seconds",
+		Help:    "gRPC request latency in seconds, labeled by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"}))
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		latency.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		result := "ok"
+		if err != nil {
+			result = status.Code(err).String()
+		}
+		requests.WithLabelValues(info.FullMethod, result).Inc()
+		return resp, err
+	}
+}
+
+// registerCounterVec registers cv against reg, or returns the
+// already-registered CounterVec of the same name if one exists.
+func registerCounterVec(reg prometheus.Registerer, cv *prometheus.CounterVec) *prometheus.CounterVec {
+	if err := reg.Register(cv); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			return are.ExistingCollector.(*prometheus.CounterVec)
+		}
+		panic(err)
+	}
+	return cv
+}
+
+// registerHistogramVec registers hv against reg, or returns the
+// already-registered HistogramVec of the same name if one exists.
+func registerHistogramVec(reg prometheus.Registerer, hv *prometheus.HistogramVec) *prometheus.HistogramVec {
+	if err := reg.Register(hv); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			return are.ExistingCollector.(*prometheus.HistogramVec)
+		}
+		panic(err)
+	}
+	return hv
+}
@@ -0,0 +1,67 @@
+package interceptor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	gpeer "google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// limiterIdleTTL is how long a peer's limiter may sit unused before
+// RateLimit's background sweep reclaims it.
+const limiterIdleTTL = 10 * time.Minute
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// RateLimit returns a unary server interceptor that enforces a per-peer
+// token-bucket limit of rps requests/sec (with the given burst capacity),
+// to protect an owner from a hot-key storm originating from a single peer.
+// Peer limiters idle for longer than limiterIdleTTL are swept so the
+// per-addr map doesn't grow without bound under churning client addresses.
+func RateLimit(rps float64, burst int) grpc.UnaryServerInterceptor {
+	var mu sync.Mutex
+	limiters := make(map[string]*limiterEntry)
+
+	go func() {
+		ticker := time.NewTicker(limiterIdleTTL)
+		defer ticker.Stop()
+		for range ticker.C {
+			mu.Lock()
+			for addr, e := range limiters {
+				if time.Since(e.lastUsed) > limiterIdleTTL {
+					delete(limiters, addr)
+				}
+			}
+			mu.Unlock()
+		}
+	}()
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		addr := "unknown"
+		if p, ok := gpeer.FromContext(ctx); ok {
+			addr = p.Addr.String()
+		}
+
+		mu.Lock()
+		e, ok := limiters[addr]
+		if !ok {
+			e = &limiterEntry{limiter: rate.NewLimiter(rate.Limit(rps), burst)}
+			limiters[addr] = e
+		}
+		e.lastUsed = time.Now()
+		mu.Unlock()
+
+		if !e.limiter.Allow() {
+			return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded for peer %s", addr)
+		}
+		return handler(ctx, req)
+	}
+}
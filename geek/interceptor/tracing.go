@@ -0,0 +1,68 @@
+package interceptor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// metadataCarrier adapts gRPC metadata to otel's TextMapCarrier so a span
+// context can be extracted from (or injected into) a request's metadata.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	vals := metadata.MD(c).Get(key)
+	if len(vals) == 0 {
+		return ""
+	}
+	return vals[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Tracing returns a unary server interceptor that extracts a propagated
+// span context from incoming metadata - so an RPC hop between peers
+// continues the caller's trace instead of starting a new one - and starts
+// a server span for the duration of the call.
+func Tracing(tracer trace.Tracer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			md = metadata.MD{}
+		}
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+		ctx, span := tracer.Start(ctx, info.FullMethod)
+		defer span.End()
+		return handler(ctx, req)
+	}
+}
+
+// InjectTracing returns a unary client interceptor that injects the
+// caller's current span context into outgoing metadata, so the peer's
+// Tracing server interceptor can continue the same trace.
+func InjectTracing() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if !ok {
+			md = metadata.MD{}
+		} else {
+			md = md.Copy()
+		}
+		otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+		ctx = metadata.NewOutgoingContext(ctx, md)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,155 @@
+package geek
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Makonike/geek-cache/geek/singleflight"
+)
+
+// LoadGroup coalesces concurrent loads for the same key so that, ideally,
+// only one backend/RPC call is in flight at a time. Server plugs a LoadGroup
+// in front of Group.Get so that many peers hammering the owner for the same
+// hot key collapse into a single Getter invocation, with the result (or
+// error) fanned out to every waiter - the same idea as groupcache's
+// singleflight, but exposed as an interface so a distributed coordinator can
+// be swapped in for cross-process coalescing.
+type LoadGroup interface {
+	// Do calls fn unless an identical call for key is already in flight, in
+	// which case it waits for and returns that call's result instead.
+	Do(key string, fn func() (ByteView, error)) (ByteView, error)
+}
+
+// localLoadGroup is the default, process-local LoadGroup. It is backed by
+// singleflight and additionally remembers recent errors for a short TTL so
+// that a burst of requests for a key that just failed (e.g. "not found")
+// doesn't re-trigger the backend Getter on every call once the in-flight
+// call has completed.
+type localLoadGroup struct {
+	g singleflight.Group
+
+	negativeTTL time.Duration
+
+	mu  sync.Mutex
+	neg map[string]negativeEntry
+}
+
+type negativeEntry struct {
+	err      error
+	expireAt time.Time
+}
+
+// NewLoadGroup returns the default LoadGroup implementation. A negativeTTL
+// of 0 disables negative-result caching.
+func NewLoadGroup(negativeTTL time.Duration) LoadGroup {
+	return &localLoadGroup{
+		negativeTTL: negativeTTL,
+		neg:         make(map[string]negativeEntry),
+	}
+}
+
+func (l *localLoadGroup) Do(key string, fn func() (ByteView, error)) (ByteView, error) {
+	if l.negativeTTL > 0 {
+		l.mu.Lock()
+		if entry, ok := l.neg[key]; ok {
+			if time.Now().Before(entry.expireAt) {
+				l.mu.Unlock()
+				return ByteView{}, entry.err
+			}
+			delete(l.neg, key)
+		}
+		l.mu.Unlock()
+	}
+
+	v, err := l.g.Do(key, func() (interface{}, error) {
+		return fn()
+	})
+	if err != nil {
+		if l.negativeTTL > 0 {
+			l.mu.Lock()
+			l.neg[key] = negativeEntry{err: err, expireAt: time.Now().Add(l.negativeTTL)}
+			l.mu.Unlock()
+		}
+		return ByteView{}, err
+	}
+	return v.(ByteView), nil
+}
+
+// Coordinator is implemented by a distributed lock + shared result store
+// (e.g. backed by Redis or etcd) that lets a distributedLoadGroup extend
+// single-process coalescing to cross-process coalescing: while one
+// process/node is loading a key, peers holding the same Coordinator wait on
+// the lock and then read the already-computed result, instead of each
+// issuing their own backend fetch once they in turn acquire the lock.
+type Coordinator interface {
+	// Lock blocks until the caller holds the lock for key, then returns a
+	// release func that must be called to give it up. Implementations
+	// should attach a reasonable lease/ttl so a crashed holder doesn't wedge
+	// the key forever.
+	Lock(ctx context.Context, key string) (release func(), err error)
+
+	// Result returns a result for key previously published via PutResult,
+	// if one is still fresh, so a caller that was waiting on Lock can use
+	// it instead of recomputing fn itself.
+	Result(ctx context.Context, key string) (value ByteView, ok bool, err error)
+
+	// PutResult publishes key's freshly computed value for other waiters to
+	// pick up via Result. Implementations should expire it after a short
+	// TTL rather than keeping it around indefinitely.
+	PutResult(ctx context.Context, key string, value ByteView) error
+}
+
+// distributedLoadGroup layers a Coordinator on top of a local LoadGroup so
+// that process-local duplicate calls coalesce via singleflight as usual,
+// while the first caller on each process additionally coalesces with other
+// processes via the Coordinator: after acquiring the lock it checks for an
+// already-published result before falling back to calling fn itself.
+type distributedLoadGroup struct {
+	local       LoadGroup
+	coord       Coordinator
+	lockTimeout time.Duration
+}
+
+// NewDistributedLoadGroup wraps local with coord so that hot-key coalescing
+// also applies across processes, not just within one. local is typically
+// the result of NewLoadGroup. lockTimeout bounds how long a caller waits on
+// coord.Lock before giving up and loading locally, so a wedged coordinator
+// can't block a load indefinitely; lockTimeout <= 0 uses a 5-second default.
+func NewDistributedLoadGroup(local LoadGroup, coord Coordinator, lockTimeout time.Duration) LoadGroup {
+	if lockTimeout <= 0 {
+		lockTimeout = 5 * time.Second
+	}
+	return &distributedLoadGroup{local: local, coord: coord, lockTimeout: lockTimeout}
+}
+
+func (d *distributedLoadGroup) Do(key string, fn func() (ByteView, error)) (ByteView, error) {
+	return d.local.Do(key, func() (ByteView, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), d.lockTimeout)
+		defer cancel()
+
+		release, err := d.coord.Lock(ctx, key)
+		if err != nil {
+			// fall back to a local-only load rather than fail the request
+			// outright when the coordinator is unavailable or the lock
+			// wait timed out
+			return fn()
+		}
+		defer release()
+
+		// another process may have computed (and published) this result
+		// while we were waiting on the lock
+		if v, ok, err := d.coord.Result(ctx, key); err == nil && ok {
+			return v, nil
+		}
+
+		v, err := fn()
+		if err != nil {
+			return v, err
+		}
+		// best-effort publish: a failure here just means the next waiter
+		// recomputes fn itself instead of reading our result
+		_ = d.coord.PutResult(ctx, key, v)
+		return v, nil
+	})
+}
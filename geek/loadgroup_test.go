@@ -0,0 +1,87 @@
+package geek
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLocalLoadGroupCachesNegativeResultUntilTTL(t *testing.T) {
+	lg := NewLoadGroup(50 * time.Millisecond)
+	wantErr := errors.New("not found")
+
+	var calls int32
+	load := func() (ByteView, error) {
+		atomic.AddInt32(&calls, 1)
+		return ByteView{}, wantErr
+	}
+
+	if _, err := lg.Do("key", load); err != wantErr {
+		t.Fatalf("first call: got err %v, want %v", err, wantErr)
+	}
+	if _, err := lg.Do("key", load); err != wantErr {
+		t.Fatalf("second call (within TTL): got err %v, want %v", err, wantErr)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("load called %d times before TTL expiry, want 1", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if _, err := lg.Do("key", load); err != wantErr {
+		t.Fatalf("third call (after TTL): got err %v, want %v", err, wantErr)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("load called %d times after TTL expiry, want 2", got)
+	}
+}
+
+func TestLocalLoadGroupDisabledNegativeTTLAlwaysRetries(t *testing.T) {
+	lg := NewLoadGroup(0)
+	wantErr := errors.New("not found")
+
+	var calls int32
+	load := func() (ByteView, error) {
+		atomic.AddInt32(&calls, 1)
+		return ByteView{}, wantErr
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := lg.Do("key", load); err != wantErr {
+			t.Fatalf("call %d: got err %v, want %v", i, err, wantErr)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Fatalf("load called %d times with negative caching disabled, want 3", got)
+	}
+}
+
+func TestLocalLoadGroupCachesSuccessResultOnlyThroughSingleflight(t *testing.T) {
+	lg := NewLoadGroup(time.Minute)
+
+	v, err := lg.Do("key", func() (ByteView, error) {
+		return ByteView{b: []byte("value")}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.String() != "value" {
+		t.Fatalf("got %q, want %q", v.String(), "value")
+	}
+
+	// A successful result isn't remembered past the in-flight call (only
+	// errors are, via the negative-TTL cache), so a second call runs fn
+	// again rather than replaying the first result forever.
+	var calls int32
+	_, err = lg.Do("key", func() (ByteView, error) {
+		atomic.AddInt32(&calls, 1)
+		return ByteView{b: []byte("value2")}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("second Do call ran fn %d times, want 1", got)
+	}
+}
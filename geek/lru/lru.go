@@ -0,0 +1,122 @@
+// Package lru implements a fixed-size, least-recently-used cache with
+// optional per-entry expiry.
+package lru
+
+import (
+	"container/list"
+	"time"
+)
+
+// Value must be implemented by cached values so the cache can track memory
+// use.
+type Value interface {
+	Len() int
+}
+
+type entry struct {
+	key       string
+	value     Value
+	expiresAt time.Time // zero means no expiry
+}
+
+// Cache is an LRU cache. It is not safe for concurrent use; callers
+// (geek.cache) are expected to guard it with their own lock.
+type Cache struct {
+	maxBytes int64
+	nbytes   int64
+	ll       *list.List
+	cache    map[string]*list.Element
+
+	// OnEvicted optionally runs whenever an entry is purged: by RemoveOldest,
+	// by Add evicting to stay under maxBytes, by Remove, or by Get dropping
+	// an expired entry.
+	OnEvicted func(key string, value Value)
+}
+
+// New returns an empty Cache. maxBytes <= 0 means no byte limit.
+func New(maxBytes int64, onEvicted func(string, Value)) *Cache {
+	return &Cache{
+		maxBytes:  maxBytes,
+		ll:        list.New(),
+		cache:     make(map[string]*list.Element),
+		OnEvicted: onEvicted,
+	}
+}
+
+// Get looks up key, treating an entry past its expiry as absent (and
+// evicting it).
+func (c *Cache) Get(key string) (Value, bool) {
+	ele, ok := c.cache[key]
+	if !ok {
+		return nil, false
+	}
+	en := ele.Value.(*entry)
+	if !en.expiresAt.IsZero() && time.Now().After(en.expiresAt) {
+		c.removeElement(ele)
+		return nil, false
+	}
+	c.ll.MoveToFront(ele)
+	return en.value, true
+}
+
+// Add inserts or updates key with no expiry.
+func (c *Cache) Add(key string, value Value) {
+	c.AddWithTTL(key, value, 0)
+}
+
+// AddWithTTL inserts or updates key, expiring it after ttl. ttl <= 0 means
+// no expiry.
+func (c *Cache) AddWithTTL(key string, value Value, ttl time.Duration) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+	if ele, ok := c.cache[key]; ok {
+		c.ll.MoveToFront(ele)
+		en := ele.Value.(*entry)
+		c.nbytes += int64(value.Len()) - int64(en.value.Len())
+		en.value = value
+		en.expiresAt = expiresAt
+		return
+	}
+	ele := c.ll.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+	c.cache[key] = ele
+	c.nbytes += int64(len(key)) + int64(value.Len())
+	for c.maxBytes > 0 && c.nbytes > c.maxBytes {
+		c.RemoveOldest()
+	}
+}
+
+// Remove evicts key, if present, regardless of expiry. It reports whether
+// an entry was actually removed.
+func (c *Cache) Remove(key string) bool {
+	ele, ok := c.cache[key]
+	if !ok {
+		return false
+	}
+	c.removeElement(ele)
+	return true
+}
+
+// RemoveOldest evicts the least-recently-used entry.
+func (c *Cache) RemoveOldest() {
+	ele := c.ll.Back()
+	if ele != nil {
+		c.removeElement(ele)
+	}
+}
+
+func (c *Cache) removeElement(ele *list.Element) {
+	c.ll.Remove(ele)
+	en := ele.Value.(*entry)
+	delete(c.cache, en.key)
+	c.nbytes -= int64(len(en.key)) + int64(en.value.Len())
+	if c.OnEvicted != nil {
+		c.OnEvicted(en.key, en.value)
+	}
+}
+
+// Len reports the number of entries currently cached.
+func (c *Cache) Len() int {
+	return c.ll.Len()
+}
@@ -4,26 +4,43 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Makonike/geek-cache/geek/consistenthash"
-	registry "github.com/Makonike/geek-cache/geek/registry"
-	clientv3 "go.etcd.io/etcd/client/v3"
+	"github.com/Makonike/geek-cache/geek/registry"
+	geeksync "github.com/Makonike/geek-cache/geek/sync"
+	"google.golang.org/grpc"
 )
 
 // PeerPicker must be implemented to locate the peer that owns a specific key
 type PeerPicker interface {
-	PickPeer(key string) (peer PeerGetter, ok bool, isSelf bool)
+	// PickPeer locates the peer that owns key. release must be called once
+	// the request against peer has completed (success or failure) so
+	// bounded-load tracking on the hash ring stays accurate.
+	PickPeer(key string) (peer PeerGetter, ok bool, isSelf bool, release func())
 }
 
 // PeerGetter must be implemented by a peer
 type PeerGetter interface {
 	Get(group string, key string) ([]byte, error)
 	Delete(group string, key string) (bool, error)
+	Set(group string, key string, value []byte, ttl time.Duration) error
 }
 
+// SetMode controls how ClientPicker.Set propagates a write to the cluster.
+type SetMode int
+
+const (
+	// SetOwnerOnly forwards the write to the single peer that owns the key
+	// on the consistent hash ring, mirroring how Get/Delete are routed.
+	SetOwnerOnly SetMode = iota
+	// SetBroadcast best-effort fans the write out to every known peer, so
+	// replicas can refresh or invalidate their own local copy.
+	SetBroadcast
+)
+
 type ClientPicker struct {
 	self        string // self ip
 	serviceName string
@@ -31,8 +48,27 @@ type ClientPicker struct {
 	consHash    *consistenthash.Map // stores the list of peers, selected by specific key
 	clients     map[string]*Client  // keyed by e.g. "10.0.0.2:8009"
 	stopSignal  chan error          // signal to stop
+
+	hotKeys  *hotKeyTracker // per-key decaying request-rate counter
+	hotCache *hotCache      // bounded local cache for keys promoted off a remote owner
+
+	setMode SetMode // how Set propagates writes across the cluster
+
+	registry registry.Registry // service discovery backend
+
+	leader     geeksync.Leader // elects exactly one node to run reshard tasks
+	reshard    RebalanceFunc   // pre-warms keys newly owned by this node after a ring change
+	resharding int32           // CAS guard: 0 idle, 1 an election/reshard is in flight
+
+	dialOpts []grpc.DialOption // extra dial options applied to every Client
 }
 
+// RebalanceFunc pre-warms keys that this node has just become responsible
+// for (as reported by diff) by fetching them from their previous owner,
+// instead of leaving every key to fall back to the origin Getter on its
+// next miss.
+type RebalanceFunc func(picker *ClientPicker, diff consistenthash.RingChangeEvent)
+
 func NewClientPicker(self string, opts ...PickerOptions) *ClientPicker {
 	picker := ClientPicker{
 		self:        self,
@@ -44,87 +80,69 @@ func NewClientPicker(self string, opts ...PickerOptions) *ClientPicker {
 	for _, opt := range opts {
 		opt(&picker)
 	}
-
+	if picker.registry == nil {
+		picker.registry = registry.NewEtcdRegistry(registry.DefaultEtcdConfig())
+	}
 	// 增量更新
 	// TODO: watch closed
 	picker.set(picker.self)
+	// Wire the reshard callback only after this node's own startup
+	// self-join, so picker.set(picker.self) above doesn't itself trigger an
+	// election before the picker is even done constructing.
+	picker.consHash.SetOnRingChanged(picker.handleRingChanged)
 	go func() {
-		cli, err := clientv3.New(*registry.GlobalClientConfig)
+		ch, err := picker.registry.Watch(context.Background(), picker.serviceName)
 		if err != nil {
 			log.Fatal(err)
 			return
 		}
-		defer cli.Close()
-		// watcher will watch for changes of the service node
-		watcher := clientv3.NewWatcher(cli)
-		watchCh := watcher.Watch(context.Background(), picker.serviceName, clientv3.WithPrefix())
-		for {
-			a := <-watchCh
-			go func() {
-				picker.mu.Lock()
-				defer picker.mu.Unlock()
-				for _, x := range a.Events {
-					// x: geek-cache/127.0.0.1:8004
-					key := string(x.Kv.Key)
-					idx := strings.Index(key, picker.serviceName)
-					addr := key[idx+len(picker.serviceName)+1:]
-					if addr == picker.self {
-						continue
-					}
-					if x.IsCreate() {
-						if _, ok := picker.clients[addr]; !ok {
-							picker.set(addr)
-						}
-					} else if x.Type == clientv3.EventTypeDelete {
-						if _, ok := picker.clients[addr]; ok {
-							picker.remove(addr)
-						}
-					}
+		for ev := range ch {
+			if ev.Addr == picker.self {
+				continue
+			}
+			picker.mu.Lock()
+			switch ev.Type {
+			case registry.EventCreate:
+				if _, ok := picker.clients[ev.Addr]; !ok {
+					picker.set(ev.Addr)
+				}
+			case registry.EventDelete:
+				if _, ok := picker.clients[ev.Addr]; ok {
+					picker.remove(ev.Addr)
 				}
-			}()
+			}
+			picker.mu.Unlock()
 		}
 	}()
 
 	// 全量更新
 	go func() {
-		picker.mu.Lock()
-		cli, err := clientv3.New(*registry.GlobalClientConfig)
-		if err != nil {
-			log.Fatal(err)
-			return
-		}
-		defer cli.Close()
 		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 		defer cancel()
-		resp, err := cli.Get(ctx, picker.serviceName, clientv3.WithPrefix())
+		addrs, err := picker.registry.List(ctx, picker.serviceName)
 		if err != nil {
 			log.Panic("[Event] full copy request failed")
 		}
-		kvs := resp.OpResponse().Get().Kvs
 
+		picker.mu.Lock()
 		defer picker.mu.Unlock()
-		for _, kv := range kvs {
-			key := string(kv.Key)
-			idx := strings.Index(key, picker.serviceName)
-			addr := key[idx+len(picker.serviceName)+1:]
-
+		for _, addr := range addrs {
+			if addr == picker.self {
+				continue
+			}
 			if _, ok := picker.clients[addr]; !ok {
 				picker.set(addr)
 			}
-
 		}
 	}()
 
 	// register itself
 	go func() {
-		err := registry.Register(picker.serviceName, picker.self, picker.stopSignal)
+		err := picker.registry.Register(picker.serviceName, picker.self, picker.stopSignal)
 		if err != nil {
 			log.Fatalf(err.Error())
 		}
 		close(picker.stopSignal)
-		if err != nil {
-			log.Fatalf(err.Error())
-		}
 		log.Printf("[%s] Revoke service and close tcp socket ok", picker.self)
 	}()
 
@@ -145,9 +163,63 @@ func ConsHashOptions(opts ...consistenthash.ConsOptions) PickerOptions {
 	}
 }
 
+// HotCacheOptions enables the local hot-cache tier: once a key owned by a
+// remote peer is requested at an estimated rate of qpsThreshold or higher,
+// it is promoted into a bounded LRU of maxEntries so subsequent requests
+// are served locally instead of over RPC. Promoted entries expire after
+// ttl (0 means they never expire on their own, relying solely on
+// InvalidateHot/LRU eviction) so a node doesn't keep serving a promoted
+// key forever after its owner's value changes. Hot caching is disabled
+// (zero value) by default.
+func HotCacheOptions(maxEntries int, qpsThreshold float64, ttl time.Duration) PickerOptions {
+	return func(picker *ClientPicker) {
+		picker.hotCache = newHotCache(maxEntries, ttl)
+		picker.hotKeys = newHotKeyTracker(qpsThreshold)
+	}
+}
+
+// WithSetMode configures how ClientPicker.Set propagates writes. The
+// default is SetOwnerOnly.
+func WithSetMode(mode SetMode) PickerOptions {
+	return func(picker *ClientPicker) {
+		picker.setMode = mode
+	}
+}
+
+// WithRegistry selects the service discovery backend used to advertise
+// self and locate other peers, e.g. registry.NewConsulRegistry,
+// registry.NewMDNSRegistry, or registry.NewGossipRegistry. Defaults to an
+// etcd-backed registry pointed at a local single-node etcd instance.
+func WithRegistry(reg registry.Registry) PickerOptions {
+	return func(picker *ClientPicker) {
+		picker.registry = reg
+	}
+}
+
+// WithReshard enables reshard-on-membership-change: whenever a peer joins
+// or leaves, leader contends for leadership of a per-picker reshard task so
+// that exactly one node in the cluster runs fn to pre-warm newly-assigned
+// keys from their previous owners.
+func WithReshard(leader geeksync.Leader, fn RebalanceFunc) PickerOptions {
+	return func(picker *ClientPicker) {
+		picker.leader = leader
+		picker.reshard = fn
+	}
+}
+
 func (p *ClientPicker) set(addr string) {
 	p.consHash.Add(addr)
-	p.clients[addr] = NewClient(addr, p.serviceName)
+	p.clients[addr] = NewClient(addr, p.serviceName, p.dialOpts...)
+}
+
+// WithDialOptions appends gRPC dial options (e.g. credentials, or
+// interceptor.InjectTracing()/other client interceptors via
+// grpc.WithChainUnaryInterceptor) applied to every peer Client this picker
+// creates.
+func WithDialOptions(opts ...grpc.DialOption) PickerOptions {
+	return func(picker *ClientPicker) {
+		picker.dialOpts = append(picker.dialOpts, opts...)
+	}
 }
 
 func (p *ClientPicker) remove(addr string) {
@@ -155,15 +227,131 @@ func (p *ClientPicker) remove(addr string) {
 	delete(p.clients, addr)
 }
 
-// PickPeer pick a peer with the consistenthash algorithm
-func (s *ClientPicker) PickPeer(key string) (PeerGetter, bool, bool) {
+// handleRingChanged is the consistenthash.Map callback for ring membership
+// changes: it contends for leadership of the picker's reshard task and, if
+// won, runs reshard with the diff so only the moved key ranges are
+// pre-warmed, then resigns so later ring changes can elect a (possibly
+// different) runner instead of leaving this node leader forever. Losing or
+// failing the election is not an error: some other node is (or will be)
+// doing the pre-warm instead. At most one election/reshard runs at a time
+// per picker; a ring change that arrives while one is still in flight is
+// dropped rather than piling up a new goroutine and session per event.
+func (p *ClientPicker) handleRingChanged(diff consistenthash.RingChangeEvent) {
+	if p.leader == nil || p.reshard == nil {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&p.resharding, 0, 1) {
+		p.Log("reshard already in flight, dropping ring-change event")
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&p.resharding, 0)
+		_, resign, err := p.leader.Elect("reshard/" + p.serviceName)
+		if err != nil {
+			p.Log("reshard election failed: %v", err)
+			return
+		}
+		defer resign()
+		p.reshard(p, diff)
+	}()
+}
+
+// PickPeer picks a peer with the consistent-hash ring, honoring bounded
+// loads if configured via consistenthash.BoundedLoad. The caller must call
+// release once its request against the returned peer has completed.
+func (s *ClientPicker) PickPeer(key string) (PeerGetter, bool, bool, func()) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	if peer := s.consHash.Get(key); peer != "" {
-		s.Log("Pick peer %s", peer)
-		return s.clients[peer], true, peer == s.self
+	peer, release := s.consHash.GetWithLoad(key)
+	if peer == "" {
+		return nil, false, false, func() {}
+	}
+	s.Log("Pick peer %s", peer)
+	isSelf := peer == s.self
+	if !isSelf && s.hotKeys != nil {
+		s.hotKeys.touch(key)
+	}
+	return s.clients[peer], true, isSelf, release
+}
+
+// HotGet returns key's value from the local hot cache, if this node has
+// promoted it, without going over the network. Callers (Group.Get) should
+// try this before calling PickPeer for a key that isn't owned locally.
+func (s *ClientPicker) HotGet(key string) (ByteView, bool) {
+	if s.hotCache == nil {
+		return ByteView{}, false
+	}
+	return s.hotCache.get(key)
+}
+
+// RecordRemoteHit should be called after successfully fetching key's value
+// from its owning peer. Once key's estimated request rate has crossed the
+// configured QPS threshold, it promotes key into the local hot cache.
+func (s *ClientPicker) RecordRemoteHit(key string, value ByteView) {
+	if s.hotCache == nil || s.hotKeys == nil {
+		return
+	}
+	if s.hotKeys.isHot(key) {
+		s.hotCache.add(key, value)
+	}
+}
+
+// HotCacheStats reports the local hot cache's size, hit rate and eviction
+// count for metrics exporters.
+func (s *ClientPicker) HotCacheStats() HotCacheStats {
+	if s.hotCache == nil {
+		return HotCacheStats{}
+	}
+	return s.hotCache.stats()
+}
+
+// HotKeys returns the keys currently promoted to the local hot cache, most
+// recently used first. It backs an admin/introspection surface for dumping
+// current hot keys (see Server.HotKeys).
+func (s *ClientPicker) HotKeys() []string {
+	if s.hotCache == nil {
+		return nil
+	}
+	return s.hotCache.keys()
+}
+
+// InvalidateHot drops key from this node's local hot cache, if it was
+// promoted there, so the next request for it goes back to the owning peer
+// instead of serving a value that may now be stale. It only reaches this
+// node's own hot cache; other nodes that separately promoted the same key
+// self-heal once their own ttl (HotCacheOptions) elapses.
+func (s *ClientPicker) InvalidateHot(key string) {
+	if s.hotCache == nil {
+		return
+	}
+	s.hotCache.invalidate(key)
+}
+
+// Set writes group/key=value with the given ttl (zero means no expiry) and
+// propagates it according to the configured SetMode: to the owning peer
+// only, or as a best-effort broadcast to every peer.
+func (s *ClientPicker) Set(group, key string, value []byte, ttl time.Duration) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.setMode == SetBroadcast {
+		var firstErr error
+		for addr, client := range s.clients {
+			if err := client.Set(group, key, value, ttl); err != nil {
+				s.Log("broadcast set to %s failed: %v", addr, err)
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+		}
+		return firstErr
+	}
+
+	owner := s.consHash.Get(key)
+	if owner == "" {
+		return fmt.Errorf("no peer available for key %s", key)
 	}
-	return nil, false, false
+	return s.clients[owner].Set(group, key, value, ttl)
 }
 
 // Log info
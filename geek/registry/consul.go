@@ -0,0 +1,137 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+)
+
+// ConsulRegistry implements Registry on top of a Consul agent, registering
+// each peer as a service instance with a TTL health check that Register
+// refreshes until it's asked to stop.
+type ConsulRegistry struct {
+	client *consulapi.Client
+}
+
+// NewConsulRegistry connects to the Consul agent described by cfg.
+func NewConsulRegistry(cfg *consulapi.Config) (*ConsulRegistry, error) {
+	client, err := consulapi.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &ConsulRegistry{client: client}, nil
+}
+
+func serviceID(serviceName, addr string) string {
+	return serviceName + "-" + addr
+}
+
+func (r *ConsulRegistry) Register(serviceName, addr string, stop <-chan error) error {
+	host, portStr, err := splitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return err
+	}
+
+	id := serviceID(serviceName, addr)
+	checkID := "check-" + id
+	reg := &consulapi.AgentServiceRegistration{
+		ID:      id,
+		Name:    serviceName,
+		Address: host,
+		Port:    port,
+		Check: &consulapi.AgentServiceCheck{
+			CheckID:                        checkID,
+			TTL:                            "10s",
+			DeregisterCriticalServiceAfter: "1m",
+		},
+	}
+	if err := r.client.Agent().ServiceRegister(reg); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return r.client.Agent().ServiceDeregister(id)
+		case <-ticker.C:
+			_ = r.client.Agent().UpdateTTL(checkID, "", consulapi.HealthPassing)
+		}
+	}
+}
+
+func (r *ConsulRegistry) Deregister(serviceName, addr string) error {
+	return r.client.Agent().ServiceDeregister(serviceID(serviceName, addr))
+}
+
+func (r *ConsulRegistry) List(ctx context.Context, serviceName string) ([]string, error) {
+	entries, _, err := r.client.Health().Service(serviceName, "", true, (&consulapi.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		addrs = append(addrs, fmt.Sprintf("%s:%d", e.Service.Address, e.Service.Port))
+	}
+	return addrs, nil
+}
+
+// Watch polls Consul's blocking-query API, translating instances added or
+// removed since the previous poll into Events.
+func (r *ConsulRegistry) Watch(ctx context.Context, serviceName string) (<-chan Event, error) {
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		var lastIndex uint64
+		seen := make(map[string]bool)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+			entries, meta, err := r.client.Health().Service(serviceName, "", true, &consulapi.QueryOptions{
+				WaitIndex: lastIndex,
+				WaitTime:  30 * time.Second,
+			})
+			if err != nil {
+				time.Sleep(time.Second)
+				continue
+			}
+			lastIndex = meta.LastIndex
+
+			current := make(map[string]bool, len(entries))
+			for _, e := range entries {
+				addr := fmt.Sprintf("%s:%d", e.Service.Address, e.Service.Port)
+				current[addr] = true
+				if !seen[addr] {
+					ch <- Event{Type: EventCreate, Addr: addr}
+				}
+			}
+			for addr := range seen {
+				if !current[addr] {
+					ch <- Event{Type: EventDelete, Addr: addr}
+				}
+			}
+			seen = current
+		}
+	}()
+	return ch, nil
+}
+
+func splitHostPort(addr string) (string, string, error) {
+	parts := strings.Split(addr, ":")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid address: %s", addr)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,128 @@
+package registry
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const etcdLeaseTTLSeconds = 5
+
+// EtcdRegistry is the original etcd-backed Registry implementation: each
+// instance registers its address under serviceName with a short-lived
+// lease and keeps it alive until Register is asked to stop, at which point
+// the lease is revoked and the key disappears immediately.
+type EtcdRegistry struct {
+	Config clientv3.Config
+}
+
+// NewEtcdRegistry returns a Registry backed by the given etcd client config.
+func NewEtcdRegistry(cfg clientv3.Config) *EtcdRegistry {
+	return &EtcdRegistry{Config: cfg}
+}
+
+// DefaultEtcdConfig points at a local single-node etcd instance.
+func DefaultEtcdConfig() clientv3.Config {
+	return clientv3.Config{Endpoints: []string{"127.0.0.1:2379"}, DialTimeout: 5 * time.Second}
+}
+
+func (r *EtcdRegistry) Register(serviceName, addr string, stop <-chan error) error {
+	cli, err := clientv3.New(r.Config)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	lease, err := cli.Grant(context.Background(), etcdLeaseTTLSeconds)
+	if err != nil {
+		return err
+	}
+	key := serviceName + "/" + addr
+	if _, err := cli.Put(context.Background(), key, addr, clientv3.WithLease(lease.ID)); err != nil {
+		return err
+	}
+	keepAlive, err := cli.KeepAlive(context.Background(), lease.ID)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stop:
+			_, err := cli.Revoke(context.Background(), lease.ID)
+			return err
+		case _, ok := <-keepAlive:
+			if !ok {
+				return nil
+			}
+		}
+	}
+}
+
+func (r *EtcdRegistry) Deregister(serviceName, addr string) error {
+	cli, err := clientv3.New(r.Config)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+	_, err = cli.Delete(context.Background(), serviceName+"/"+addr)
+	return err
+}
+
+func (r *EtcdRegistry) List(ctx context.Context, serviceName string) ([]string, error) {
+	cli, err := clientv3.New(r.Config)
+	if err != nil {
+		return nil, err
+	}
+	defer cli.Close()
+	resp, err := cli.Get(ctx, serviceName, clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		addrs = append(addrs, addrFromKey(serviceName, string(kv.Key)))
+	}
+	return addrs, nil
+}
+
+func (r *EtcdRegistry) Watch(ctx context.Context, serviceName string) (<-chan Event, error) {
+	cli, err := clientv3.New(r.Config)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan Event)
+	watcher := clientv3.NewWatcher(cli)
+	watchCh := watcher.Watch(ctx, serviceName, clientv3.WithPrefix())
+	go func() {
+		defer cli.Close()
+		defer watcher.Close()
+		defer close(ch)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case resp, ok := <-watchCh:
+				if !ok {
+					return
+				}
+				for _, ev := range resp.Events {
+					addr := addrFromKey(serviceName, string(ev.Kv.Key))
+					if ev.IsCreate() {
+						ch <- Event{Type: EventCreate, Addr: addr}
+					} else if ev.Type == clientv3.EventTypeDelete {
+						ch <- Event{Type: EventDelete, Addr: addr}
+					}
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func addrFromKey(serviceName, key string) string {
+	idx := strings.Index(key, serviceName)
+	return key[idx+len(serviceName)+1:]
+}
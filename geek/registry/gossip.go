@@ -0,0 +1,140 @@
+package registry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+)
+
+// GossipRegistry uses a SWIM-based memberlist cluster for registry-less
+// deployments: peers discover each other by gossiping membership instead
+// of relying on an external coordinator like etcd or Consul.
+//
+// The gossip transport's own bind address is unrelated to the cache gRPC
+// service address peers need to dial, so GossipRegistry advertises the
+// latter via memberlist node metadata (GossipRegistry implements
+// memberlist.Delegate itself) rather than returning the transport address
+// from List/Watch.
+type GossipRegistry struct {
+	list *memberlist.Memberlist
+
+	mu   sync.Mutex
+	addr string // service addr advertised via node metadata, set by Register
+}
+
+// NewGossipRegistry starts (or joins, if seeds is non-empty) a memberlist
+// cluster bound to bindAddr:bindPort.
+func NewGossipRegistry(bindAddr string, bindPort int, seeds []string) (*GossipRegistry, error) {
+	r := &GossipRegistry{}
+	cfg := memberlist.DefaultLANConfig()
+	cfg.BindAddr = bindAddr
+	cfg.BindPort = bindPort
+	cfg.Delegate = r
+	list, err := memberlist.Create(cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.list = list
+	if len(seeds) > 0 {
+		if _, err := list.Join(seeds); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *GossipRegistry) Register(serviceName, addr string, stop <-chan error) error {
+	r.mu.Lock()
+	r.addr = addr
+	r.mu.Unlock()
+	// Push the new node metadata out immediately instead of waiting for the
+	// next periodic gossip round, so peers can dial this node right away
+	// rather than only after that round.
+	if err := r.list.UpdateNode(5 * time.Second); err != nil {
+		return err
+	}
+	<-stop
+	return r.list.Leave(5 * time.Second)
+}
+
+func (r *GossipRegistry) Deregister(serviceName, addr string) error {
+	return r.list.Leave(5 * time.Second)
+}
+
+func (r *GossipRegistry) List(ctx context.Context, serviceName string) ([]string, error) {
+	members := r.list.Members()
+	addrs := make([]string, 0, len(members))
+	for _, m := range members {
+		if addr := string(m.Meta); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs, nil
+}
+
+// Watch polls the local memberlist view for membership changes, since
+// memberlist's own push notifications are delivered via an EventDelegate
+// configured at Create time rather than a channel.
+func (r *GossipRegistry) Watch(ctx context.Context, serviceName string) (<-chan Event, error) {
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		seen := make(map[string]bool)
+		ticker := time.NewTicker(2 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				current := make(map[string]bool)
+				for _, m := range r.list.Members() {
+					addr := string(m.Meta)
+					if addr == "" {
+						// hasn't advertised its service addr yet (still
+						// joining, or Register hasn't run); nothing to
+						// report until it does
+						continue
+					}
+					current[addr] = true
+					if !seen[addr] {
+						ch <- Event{Type: EventCreate, Addr: addr}
+					}
+				}
+				for addr := range seen {
+					if !current[addr] {
+						ch <- Event{Type: EventDelete, Addr: addr}
+					}
+				}
+				seen = current
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// NodeMeta implements memberlist.Delegate, advertising the registered
+// service addr (if any) as this node's gossiped metadata.
+func (r *GossipRegistry) NodeMeta(limit int) []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return []byte(r.addr)
+}
+
+// NotifyMsg implements memberlist.Delegate. GossipRegistry has no use for
+// user messages, so it ignores them.
+func (r *GossipRegistry) NotifyMsg([]byte) {}
+
+// GetBroadcasts implements memberlist.Delegate. GossipRegistry has nothing
+// to broadcast beyond node metadata.
+func (r *GossipRegistry) GetBroadcasts(overhead, limit int) [][]byte { return nil }
+
+// LocalState implements memberlist.Delegate. GossipRegistry has no
+// additional state to push during a push/pull sync.
+func (r *GossipRegistry) LocalState(join bool) []byte { return nil }
+
+// MergeRemoteState implements memberlist.Delegate, discarding remote
+// push/pull state since GossipRegistry doesn't use it.
+func (r *GossipRegistry) MergeRemoteState(buf []byte, join bool) {}
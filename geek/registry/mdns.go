@@ -0,0 +1,105 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/mdns"
+)
+
+// MDNSRegistry discovers peers via multicast DNS, requiring no external
+// coordinator - suitable for zero-config local clusters such as a
+// single-LAN demo or development setup.
+type MDNSRegistry struct {
+	domain string
+}
+
+// NewMDNSRegistry returns an mDNS-backed Registry using the standard
+// "local." domain.
+func NewMDNSRegistry() *MDNSRegistry {
+	return &MDNSRegistry{domain: "local."}
+}
+
+func (r *MDNSRegistry) Register(serviceName, addr string, stop <-chan error) error {
+	host, portStr, err := splitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return err
+	}
+	service, err := mdns.NewMDNSService(addr, "_"+serviceName+"._tcp", r.domain, "", port, []net.IP{net.ParseIP(host)}, nil)
+	if err != nil {
+		return err
+	}
+	server, err := mdns.NewServer(&mdns.Config{Zone: service})
+	if err != nil {
+		return err
+	}
+	<-stop
+	return server.Shutdown()
+}
+
+func (r *MDNSRegistry) Deregister(serviceName, addr string) error {
+	// mDNS has no central record to delete: Register's own server shutdown
+	// on stop is what removes addr from the network.
+	return nil
+}
+
+func (r *MDNSRegistry) List(ctx context.Context, serviceName string) ([]string, error) {
+	entriesCh := make(chan *mdns.ServiceEntry, 16)
+	go func() {
+		mdns.Query(&mdns.QueryParam{
+			Service: "_" + serviceName + "._tcp",
+			Domain:  strings.TrimSuffix(r.domain, "."),
+			Timeout: 2 * time.Second,
+			Entries: entriesCh,
+		})
+		close(entriesCh)
+	}()
+	var addrs []string
+	for e := range entriesCh {
+		addrs = append(addrs, fmt.Sprintf("%s:%d", e.AddrV4, e.Port))
+	}
+	return addrs, nil
+}
+
+func (r *MDNSRegistry) Watch(ctx context.Context, serviceName string) (<-chan Event, error) {
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		seen := make(map[string]bool)
+		ticker := time.NewTicker(3 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				addrs, err := r.List(ctx, serviceName)
+				if err != nil {
+					continue
+				}
+				current := make(map[string]bool, len(addrs))
+				for _, addr := range addrs {
+					current[addr] = true
+					if !seen[addr] {
+						ch <- Event{Type: EventCreate, Addr: addr}
+					}
+				}
+				for addr := range seen {
+					if !current[addr] {
+						ch <- Event{Type: EventDelete, Addr: addr}
+					}
+				}
+				seen = current
+			}
+		}
+	}()
+	return ch, nil
+}
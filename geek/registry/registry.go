@@ -0,0 +1,40 @@
+// Package registry abstracts the service-discovery backend used to
+// advertise and locate geek-cache peers, so that callers such as
+// ClientPicker don't need to know whether peers are tracked via etcd,
+// Consul, mDNS, or a gossip protocol.
+package registry
+
+import "context"
+
+// EventType describes what happened to a service instance observed by a
+// Registry watch.
+type EventType int
+
+const (
+	// EventCreate means addr was newly registered under the service.
+	EventCreate EventType = iota
+	// EventDelete means addr was removed (deregistered, or its lease/check
+	// expired).
+	EventDelete
+)
+
+// Event is a single service-instance change delivered by Registry.Watch.
+type Event struct {
+	Type EventType
+	Addr string // e.g. "127.0.0.1:8004"
+}
+
+// Registry is implemented by a service-discovery backend.
+type Registry interface {
+	// Register advertises addr under serviceName and blocks, keeping the
+	// registration alive, until stop fires - at which point it deregisters
+	// addr and returns.
+	Register(serviceName, addr string, stop <-chan error) error
+	// Deregister removes addr from serviceName immediately.
+	Deregister(serviceName, addr string) error
+	// List returns every address currently registered under serviceName.
+	List(ctx context.Context, serviceName string) ([]string, error)
+	// Watch streams create/delete events for serviceName until ctx is
+	// done, at which point the returned channel is closed.
+	Watch(ctx context.Context, serviceName string) (<-chan Event, error)
+}
@@ -7,6 +7,7 @@ import (
 	"net"
 	"strings"
 	"sync"
+	"time"
 
 	pb "github.com/Makonike/geek-cache/geek/pb"
 	"github.com/Makonike/geek-cache/geek/utils"
@@ -21,9 +22,14 @@ const (
 
 type Server struct {
 	pb.UnimplementedGroupCacheServer
-	self   string     // self ip
-	status bool       // true if the server is running
-	mu     sync.Mutex // guards
+	self      string        // self ip
+	status    bool          // true if the server is running
+	mu        sync.Mutex    // guards
+	loadGroup LoadGroup     // coalesces concurrent Get calls for the same key
+	picker    *ClientPicker // backs the HotKeys admin RPC; nil if never set
+
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
 }
 
 type ServerOptions func(*Server)
@@ -35,7 +41,8 @@ func NewServer(self string, opts ...ServerOptions) (*Server, error) {
 		return nil, fmt.Errorf("invalid address: %v", self)
 	}
 	s := Server{
-		self: self,
+		self:      self,
+		loadGroup: NewLoadGroup(0),
 	}
 	for _, opt := range opts {
 		opt(&s)
@@ -43,6 +50,40 @@ func NewServer(self string, opts ...ServerOptions) (*Server, error) {
 	return &s, nil
 }
 
+// ServerLoadGroup overrides the default, process-local LoadGroup, e.g. with
+// one built from NewDistributedLoadGroup for cross-process coalescing on
+// hot keys.
+func ServerLoadGroup(lg LoadGroup) ServerOptions {
+	return func(s *Server) {
+		s.loadGroup = lg
+	}
+}
+
+// ServerPeerPicker wires picker into the server so admin surfaces like the
+// HotKeys RPC can reach its hot-cache state. It's optional: a Server with
+// no picker set just reports an empty HotKeys response.
+func ServerPeerPicker(picker *ClientPicker) ServerOptions {
+	return func(s *Server) {
+		s.picker = picker
+	}
+}
+
+// ServerUnaryInterceptors appends to the chain of unary interceptors run
+// around Get/Delete/Set, in order. See the interceptor package for the
+// built-in auth, tracing, metrics and rate-limiting interceptors.
+func ServerUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) ServerOptions {
+	return func(s *Server) {
+		s.unaryInterceptors = append(s.unaryInterceptors, interceptors...)
+	}
+}
+
+// ServerStreamInterceptors appends to the chain of stream interceptors.
+func ServerStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) ServerOptions {
+	return func(s *Server) {
+		s.streamInterceptors = append(s.streamInterceptors, interceptors...)
+	}
+}
+
 func (s *Server) Get(ctx context.Context, in *pb.Request) (*pb.ResponseForGet, error) {
 	group, key := in.GetGroup(), in.GetKey()
 	out := &pb.ResponseForGet{}
@@ -55,11 +96,16 @@ func (s *Server) Get(ctx context.Context, in *pb.Request) (*pb.ResponseForGet, e
 	if g == nil {
 		return out, fmt.Errorf("group not found")
 	}
-	view, err := g.Get(key)
+	// dedupe concurrent Get's for the same group/key: only one of them
+	// actually calls g.Get (and thus the backend Getter on a local miss),
+	// the rest wait for and share its result
+	view, err := s.loadGroup.Do(group+"/"+key, func() (ByteView, error) {
+		return g.Get(key)
+	})
 	if err != nil {
 		return out, err
 	}
-	out.Value = view.ByteSLice()
+	out.Value = view.ByteSlice()
 	return out, nil
 }
 
@@ -83,6 +129,60 @@ func (s *Server) Delete(ctx context.Context, in *pb.Request) (*pb.ResponseForDel
 	return out, nil
 }
 
+func (s *Server) Set(ctx context.Context, in *pb.SetRequest) (*pb.ResponseForSet, error) {
+	group, key := in.GetGroup(), in.GetKey()
+	out := &pb.ResponseForSet{}
+	log.Printf("[Geek-Cache %s] Recv RPC Request for set - (%s)/(%s)", s.self, group, key)
+
+	if key == "" {
+		return out, fmt.Errorf("key required")
+	}
+	g := GetGroup(group)
+	if g == nil {
+		return out, fmt.Errorf("group not found")
+	}
+
+	ttl := time.Duration(in.GetTtlMs()) * time.Millisecond
+	if expireAt := in.GetExpireAt(); expireAt > 0 {
+		d := time.Until(time.Unix(expireAt, 0))
+		if d <= 0 {
+			// expire_at already elapsed: honor it by treating the key as
+			// already-expired (dropping any existing value) rather than
+			// silently falling back to ttl_ms and storing it with no
+			// expiry at all.
+			if _, err := g.Delete(key); err != nil {
+				return out, err
+			}
+			out.Value = true
+			return out, nil
+		}
+		ttl = d
+	}
+
+	if err := g.Set(key, in.GetValue(), ttl); err != nil {
+		return out, err
+	}
+	out.Value = true
+	return out, nil
+}
+
+// HotKeys is an admin RPC that dumps the keys currently promoted to this
+// node's local hot cache, along with its hit/miss/eviction counters. It
+// reports an empty response on a Server with no picker configured, rather
+// than erroring.
+func (s *Server) HotKeys(ctx context.Context, in *pb.HotKeysRequest) (*pb.HotKeysResponse, error) {
+	out := &pb.HotKeysResponse{}
+	if s.picker == nil {
+		return out, nil
+	}
+	out.Keys = s.picker.HotKeys()
+	stats := s.picker.HotCacheStats()
+	out.Hits = stats.Hits
+	out.Misses = stats.Misses
+	out.Evictions = stats.Evictions
+	return out, nil
+}
+
 func (s *Server) Start() error {
 	s.mu.Lock()
 	if s.status {
@@ -96,7 +196,10 @@ func (s *Server) Start() error {
 	if err != nil {
 		return fmt.Errorf("failed to listen on %s: %v", port, err)
 	}
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.unaryInterceptors...),
+		grpc.ChainStreamInterceptor(s.streamInterceptors...),
+	)
 	pb.RegisterGroupCacheServer(grpcServer, s)
 	// 启动 reflection 反射服务
 	reflection.Register(grpcServer)
@@ -0,0 +1,97 @@
+package singleflight
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDoDedupesConcurrentCalls(t *testing.T) {
+	var g Group
+	var calls int32
+
+	const n = 50
+	var wg sync.WaitGroup
+	results := make([]interface{}, n)
+	errs := make([]error, n)
+
+	// Hold the in-flight call open long enough for all n goroutines to
+	// queue up behind it; without the sleep, each call could complete (and
+	// be removed from the dedup map) before the next one even starts.
+	var started sync.WaitGroup
+	started.Add(1)
+	release := make(chan struct{})
+
+	start := make(chan struct{})
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			results[i], errs[i] = g.Do("key", func() (interface{}, error) {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					started.Done()
+				}
+				<-release
+				return "value", nil
+			})
+		}(i)
+	}
+	close(start)
+	started.Wait()
+	time.Sleep(20 * time.Millisecond) // let the rest pile up behind the lock
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("fn called %d times, want exactly 1", got)
+	}
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i, err)
+		}
+		if results[i] != "value" {
+			t.Fatalf("call %d: got %v, want %q", i, results[i], "value")
+		}
+	}
+}
+
+func TestDoSequentialCallsRunIndependently(t *testing.T) {
+	var g Group
+	var calls int32
+
+	for i := 0; i < 3; i++ {
+		v, err := g.Do("key", func() (interface{}, error) {
+			return atomic.AddInt32(&calls, 1), nil
+		})
+		if err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i, err)
+		}
+		if v.(int32) != int32(i+1) {
+			t.Fatalf("call %d: got %v, want %d", i, v, i+1)
+		}
+	}
+}
+
+func TestDoDifferentKeysDoNotDedupe(t *testing.T) {
+	var g Group
+	var calls int32
+
+	if _, err := g.Do("a", func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := g.Do("b", func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("fn called %d times across distinct keys, want 2", got)
+	}
+}
@@ -0,0 +1,126 @@
+package sync
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+)
+
+const (
+	consulLockPrefix   = "geek-cache/locks/"
+	consulLeaderPrefix = "geek-cache/leader/"
+)
+
+// ConsulLock implements Lock using a Consul session and the KV store's
+// check-and-set Acquire/Release semantics.
+type ConsulLock struct {
+	client *consulapi.Client
+
+	mu   sync.Mutex
+	held map[string]string // id -> session ID
+}
+
+func NewConsulLock(client *consulapi.Client) *ConsulLock {
+	return &ConsulLock{client: client, held: make(map[string]string)}
+}
+
+func (l *ConsulLock) Acquire(id string, ttl time.Duration) error {
+	sessionID, _, err := l.client.Session().Create(&consulapi.SessionEntry{
+		TTL:      ttl.String(),
+		Behavior: consulapi.SessionBehaviorRelease,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	key := consulLockPrefix + id
+	deadline := time.Now().Add(ttl)
+	for time.Now().Before(deadline) {
+		acquired, _, err := l.client.KV().Acquire(&consulapi.KVPair{Key: key, Session: sessionID}, nil)
+		if err != nil {
+			l.client.Session().Destroy(sessionID, nil)
+			return err
+		}
+		if acquired {
+			l.mu.Lock()
+			l.held[id] = sessionID
+			l.mu.Unlock()
+			return nil
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	l.client.Session().Destroy(sessionID, nil)
+	return fmt.Errorf("timed out acquiring lock %s", id)
+}
+
+func (l *ConsulLock) Release(id string) error {
+	l.mu.Lock()
+	sessionID, ok := l.held[id]
+	delete(l.held, id)
+	l.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("lock %s not held", id)
+	}
+	key := consulLockPrefix + id
+	if _, _, err := l.client.KV().Release(&consulapi.KVPair{Key: key, Session: sessionID}, nil); err != nil {
+		return err
+	}
+	_, err := l.client.Session().Destroy(sessionID, nil)
+	return err
+}
+
+// ConsulLeader implements Leader by having every candidate contend for the
+// same KV key via Consul session Acquire, the same building block Consul's
+// own leader-election pattern recommends.
+type ConsulLeader struct {
+	client *consulapi.Client
+}
+
+func NewConsulLeader(client *consulapi.Client) *ConsulLeader {
+	return &ConsulLeader{client: client}
+}
+
+func (l *ConsulLeader) Elect(id string) (<-chan struct{}, func(), error) {
+	sessionID, _, err := l.client.Session().Create(&consulapi.SessionEntry{
+		TTL:      "10s",
+		Behavior: consulapi.SessionBehaviorRelease,
+	}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// A single try-acquire: if some other node already holds the key,
+	// Acquire just reports acquired=false rather than blocking, so (unlike
+	// looping/sleeping until it frees up) a loser finds out immediately.
+	key := consulLeaderPrefix + id
+	acquired, _, err := l.client.KV().Acquire(&consulapi.KVPair{Key: key, Session: sessionID}, nil)
+	if err != nil {
+		l.client.Session().Destroy(sessionID, nil)
+		return nil, nil, err
+	}
+	if !acquired {
+		l.client.Session().Destroy(sessionID, nil)
+		return nil, nil, fmt.Errorf("did not win election for %s: already held", id)
+	}
+
+	lost := make(chan struct{})
+	stopRenew := make(chan struct{})
+	go func() {
+		defer close(lost)
+		// RenewPeriodic blocks until stopRenew is closed (via resign) or a
+		// renewal fails, either of which means leadership has been lost.
+		l.client.Session().RenewPeriodic("10s", sessionID, nil, stopRenew)
+	}()
+
+	var once sync.Once
+	resign := func() {
+		once.Do(func() {
+			close(stopRenew)
+			l.client.KV().Release(&consulapi.KVPair{Key: key, Session: sessionID}, nil)
+			l.client.Session().Destroy(sessionID, nil)
+		})
+	}
+	return lost, resign, nil
+}
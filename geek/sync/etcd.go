@@ -0,0 +1,119 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+const (
+	lockPrefix   = "geek-cache/locks/"
+	leaderPrefix = "geek-cache/leader/"
+
+	// electTryTimeout bounds how long Elect waits to win the election
+	// before giving up. concurrency.Election.Campaign blocks until it
+	// acquires leadership, so without a short-lived ctx every candidate
+	// would simply queue up and win in turn instead of the election ever
+	// having a loser.
+	electTryTimeout = 2 * time.Second
+)
+
+type heldLock struct {
+	session *concurrency.Session
+	mutex   *concurrency.Mutex
+}
+
+// EtcdLock implements Lock on top of etcd's concurrency package: each
+// Acquire opens a TTL-bound session and takes a session-scoped mutex under
+// lockPrefix+id.
+type EtcdLock struct {
+	client *clientv3.Client
+
+	mu   sync.Mutex
+	held map[string]*heldLock
+}
+
+func NewEtcdLock(client *clientv3.Client) *EtcdLock {
+	return &EtcdLock{client: client, held: make(map[string]*heldLock)}
+}
+
+func (l *EtcdLock) Acquire(id string, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), ttl)
+	defer cancel()
+
+	session, err := concurrency.NewSession(l.client, concurrency.WithTTL(int(ttl.Seconds())))
+	if err != nil {
+		return err
+	}
+	mutex := concurrency.NewMutex(session, lockPrefix+id)
+	if err := mutex.Lock(ctx); err != nil {
+		session.Close()
+		return err
+	}
+
+	l.mu.Lock()
+	l.held[id] = &heldLock{session: session, mutex: mutex}
+	l.mu.Unlock()
+	return nil
+}
+
+func (l *EtcdLock) Release(id string) error {
+	l.mu.Lock()
+	h, ok := l.held[id]
+	delete(l.held, id)
+	l.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("lock %s not held", id)
+	}
+	if err := h.mutex.Unlock(context.Background()); err != nil {
+		return err
+	}
+	return h.session.Close()
+}
+
+// EtcdLeader implements Leader on top of etcd's concurrency.Election.
+type EtcdLeader struct {
+	client *clientv3.Client
+}
+
+func NewEtcdLeader(client *clientv3.Client) *EtcdLeader {
+	return &EtcdLeader{client: client}
+}
+
+func (l *EtcdLeader) Elect(id string) (<-chan struct{}, func(), error) {
+	session, err := concurrency.NewSession(l.client)
+	if err != nil {
+		return nil, nil, err
+	}
+	election := concurrency.NewElection(session, leaderPrefix+id)
+
+	// A single, bounded-time attempt: if someone else already holds the
+	// election, Campaign blocks past electTryTimeout rather than
+	// returning, so we treat that the same as losing outright instead of
+	// waiting for them to resign.
+	ctx, cancel := context.WithTimeout(context.Background(), electTryTimeout)
+	defer cancel()
+	if err := election.Campaign(ctx, id); err != nil {
+		session.Close()
+		return nil, nil, fmt.Errorf("did not win election for %s: %w", id, err)
+	}
+
+	lost := make(chan struct{})
+	go func() {
+		defer close(lost)
+		<-session.Done()
+	}()
+
+	var once sync.Once
+	resign := func() {
+		once.Do(func() {
+			election.Resign(context.Background())
+			session.Close()
+		})
+	}
+	return lost, resign, nil
+}
@@ -0,0 +1,34 @@
+// Package sync provides distributed coordination primitives that
+// geek-cache uses internally for actions that must run on exactly one
+// node at a time, such as cache warm-up, bulk invalidation, and rebalancing
+// when the hash ring changes.
+package sync
+
+import "time"
+
+// Lock is a distributed mutual-exclusion primitive.
+type Lock interface {
+	// Acquire blocks until it holds the lock identified by id, or returns
+	// an error if it can't within ttl.
+	Acquire(id string, ttl time.Duration) error
+	// Release gives up the lock identified by id.
+	Release(id string) error
+}
+
+// Leader provides leader election scoped to id: at most one participant
+// across the cluster holds leadership for a given id at a time.
+type Leader interface {
+	// Elect makes a single, bounded-time attempt to become leader for id;
+	// it does not block indefinitely waiting for the current leader to
+	// step down. On success it returns a channel that's closed when
+	// leadership is lost (e.g. the backing session expires or is revoked)
+	// and a resign func that voluntarily gives up leadership and releases
+	// the backing session. Callers that win the election must call resign
+	// exactly once when they're done holding leadership, or the session
+	// (and the goroutine watching it) leaks for the life of the process.
+	// If some other participant already holds leadership for id, Elect
+	// returns a non-nil error rather than waiting for it to free up, so
+	// callers doing "exactly one of us runs this" can treat a lost
+	// election the same as any other failure to acquire.
+	Elect(id string) (lost <-chan struct{}, resign func(), err error)
+}